@@ -79,6 +79,7 @@ func (ve *ValidationError) PrintValidationError() {
 type APIError struct {
 	StatusCode int
 	Body       string
+	Summary    string
 }
 
 // NewAPIError creates a new APIError
@@ -91,24 +92,37 @@ func NewAPIError(statusCode int, body string) *APIError {
 
 // Error implements the error interface for APIError
 func (ae *APIError) Error() string {
+	if ae.Summary != "" {
+		return fmt.Sprintf("API error (status code %d): %s", ae.StatusCode, ae.Summary)
+	}
 	return fmt.Sprintf("API error (status code %d):\n%s\n", ae.StatusCode, ae.Body)
 }
 
 // PrintAPIError prints the API error to the console
 func (ae *APIError) PrintAPIError() {
+	if ae.Summary != "" {
+		fmt.Printf("API error (status code %d): %s\n", ae.StatusCode, ae.Summary)
+		return
+	}
 	fmt.Printf("API error (status code %d):\n%s\n", ae.StatusCode, ae.Body)
 }
 
 // ParseAPIError determines the type of error and returns the appropriate error struct
 func ParseAPIError(statusCode int, body string) error {
-	switch statusCode {
-	case http.StatusUnprocessableEntity:
+	if statusCode == http.StatusUnprocessableEntity {
 		var validationErr ValidationError
 		if err := json.Unmarshal([]byte(body), &validationErr); err == nil {
 			return &validationErr
 		}
-	default:
-		return NewAPIError(statusCode, body)
 	}
-	return nil
+
+	// Other errors carry a summary of what went wrong when the body is JSON
+	apiErr := NewAPIError(statusCode, body)
+	var errorBody struct {
+		Summary string `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(body), &errorBody); err == nil {
+		apiErr.Summary = errorBody.Summary
+	}
+	return apiErr
 }
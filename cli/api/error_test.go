@@ -0,0 +1,64 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseAPIError(t *testing.T) {
+	t.Run("validation error", func(t *testing.T) {
+		body := `{"summary": "Validation failed", "validation_errors": {"name": ["can't be blank"]}, "code": "invalid"}`
+		err := ParseAPIError(http.StatusUnprocessableEntity, body)
+
+		validationErr, ok := err.(*ValidationError)
+		if !ok {
+			t.Fatalf("ParseAPIError() = %T, want *ValidationError", err)
+		}
+		if validationErr.Summary != "Validation failed" || validationErr.Code != "invalid" {
+			t.Errorf("ValidationError = %+v, want summary and code from body", validationErr)
+		}
+		if !strings.Contains(err.Error(), "name: can't be blank") {
+			t.Errorf("Error() = %q, want field error", err.Error())
+		}
+	})
+
+	t.Run("unparseable validation error", func(t *testing.T) {
+		err := ParseAPIError(http.StatusUnprocessableEntity, "not json")
+
+		apiErr, ok := err.(*APIError)
+		if !ok {
+			t.Fatalf("ParseAPIError() = %T, want *APIError", err)
+		}
+		if apiErr.Body != "not json" {
+			t.Errorf("APIError.Body = %q, want %q", apiErr.Body, "not json")
+		}
+	})
+
+	t.Run("error with summary", func(t *testing.T) {
+		err := ParseAPIError(http.StatusUnauthorized, `{"summary": "The API token you provided is invalid"}`)
+
+		apiErr, ok := err.(*APIError)
+		if !ok {
+			t.Fatalf("ParseAPIError() = %T, want *APIError", err)
+		}
+		if apiErr.Summary != "The API token you provided is invalid" {
+			t.Errorf("APIError.Summary = %q, want summary from body", apiErr.Summary)
+		}
+		if err.Error() != "API error (status code 401): The API token you provided is invalid" {
+			t.Errorf("Error() = %q", err.Error())
+		}
+	})
+
+	t.Run("error without JSON body", func(t *testing.T) {
+		err := ParseAPIError(http.StatusBadGateway, "Bad Gateway")
+
+		apiErr, ok := err.(*APIError)
+		if !ok {
+			t.Fatalf("ParseAPIError() = %T, want *APIError", err)
+		}
+		if apiErr.Summary != "" || !strings.Contains(err.Error(), "Bad Gateway") {
+			t.Errorf("Error() = %q, want raw body", err.Error())
+		}
+	})
+}
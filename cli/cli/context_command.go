@@ -23,6 +23,8 @@ type ctxCommand struct {
 	apiToken      string
 	tunnelPorts   string // New field for tunnel ports
 	force         bool   // New field for force edit
+	clientCert    string
+	clientKey     string
 }
 
 func AddContextCommands(app *fisk.Application, _config *Config) {
@@ -47,6 +49,10 @@ func AddContextCommands(app *fisk.Application, _config *Config) {
 		StringVar(&cmd.apiToken)
 	add.Flag("tunnel-ports", "Comma-separated list of tunnel ports in the format port:nameOrId").
 		StringVar(&cmd.tunnelPorts)
+	add.Flag("client-cert", "Path to a client certificate for mutual TLS").
+		StringVar(&cmd.clientCert)
+	add.Flag("client-key", "Path to the private key for the client certificate").
+		StringVar(&cmd.clientKey)
 
 	ctx.Command("ls", "List all contexts").Action(cmd.listAction)
 
@@ -57,6 +63,9 @@ func AddContextCommands(app *fisk.Application, _config *Config) {
 	rm := ctx.Command("rm", "Remove a context").Action(cmd.removeAction)
 	rm.Arg("name", "The context name").StringVar(&cmd.name)
 
+	verify := ctx.Command("verify", "Check that a context can reach its server and authenticate").Action(cmd.verifyAction)
+	verify.Arg("name", "The context name").StringVar(&cmd.name)
+
 	selectCmd := ctx.Command("select", "Select a default context").Action(cmd.selectAction)
 	selectCmd.Arg("name", "The context name").StringVar(&cmd.name)
 
@@ -69,6 +78,8 @@ func AddContextCommands(app *fisk.Application, _config *Config) {
 		BoolVar(&cmd.tls)
 	edit.Flag("api-token", "The API Token for this context").StringVar(&cmd.apiToken)
 	edit.Flag("tunnel-ports", "Comma-separated list of tunnel ports in the format port:nameOrId").StringVar(&cmd.tunnelPorts)
+	edit.Flag("client-cert", "Path to a client certificate for mutual TLS").StringVar(&cmd.clientCert)
+	edit.Flag("client-key", "Path to the private key for the client certificate").StringVar(&cmd.clientKey)
 	edit.Flag("force", "Force edit without confirmation").BoolVar(&cmd.force)
 }
 
@@ -120,6 +131,13 @@ func (c *ctxCommand) addAction(pctx *fisk.ParseContext) error {
 		ctx.TunnelPorts = tunnelPorts
 	}
 
+	if c.clientCert != "" || c.clientKey != "" {
+		err := context.SetClientCertificate(&ctx, c.clientCert, c.clientKey)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := context.SaveContext(ctx)
 	if err != nil {
 		return fmt.Errorf("could not save context: %w", err)
@@ -240,6 +258,11 @@ func (c *ctxCommand) infoAction(_ *fisk.ParseContext) error {
 		rows = append(rows, table.Row{"Tunnel Ports", tunnelPortsStr})
 	}
 
+	if ctx.ClientCertFile != "" {
+		rows = append(rows, table.Row{"Client Cert", ctx.ClientCertFile})
+		rows = append(rows, table.Row{"Client Key", ctx.ClientKeyFile})
+	}
+
 	t := NewTable(columns, rows, PrintableTable)
 	fmt.Printf("Information for Context %s\n", ctx.Name)
 	return t.Render()
@@ -266,6 +289,21 @@ func (c *ctxCommand) removeAction(_ *fisk.ParseContext) error {
 	return nil
 }
 
+func (c *ctxCommand) verifyAction(_ *fisk.ParseContext) error {
+	ctx, err := context.LoadContext(c.name)
+	if err != nil {
+		return fmt.Errorf("could not load context: %w", err)
+	}
+
+	err = context.Verify(ctx)
+	if err != nil {
+		return fmt.Errorf("could not verify context '%s': %w", ctx.Name, err)
+	}
+
+	fmt.Print(text.FgGreen.Sprintf("Context '%s' can reach %s and is authorized.\n", ctx.Name, ctx.Hostname))
+	return nil
+}
+
 func (c *ctxCommand) pickContext(message string) error {
 	contexts, err := context.ListContexts()
 	if err != nil {
@@ -395,6 +433,19 @@ func (c *ctxCommand) editAction(_ *fisk.ParseContext) error {
 		}
 		newCtx.TunnelPorts = tunnelPorts
 	}
+	if c.clientCert != "" || c.clientKey != "" {
+		certFile, keyFile := c.clientCert, c.clientKey
+		if certFile == "" {
+			certFile = newCtx.ClientCertFile
+		}
+		if keyFile == "" {
+			keyFile = newCtx.ClientKeyFile
+		}
+		err := context.SetClientCertificate(&newCtx, certFile, keyFile)
+		if err != nil {
+			return err
+		}
+	}
 
 	// Compare the configurations
 	diff := cmp.Diff(existingCtx, &newCtx)
@@ -25,7 +25,7 @@ func addCheat(name string, cmd *fisk.CmdClause) {
 	cmd.CheatFile(fs, name, fmt.Sprintf("cheats/%s.md", name))
 }
 
-func formatBytes(bytes int) string {
+func formatBytes(bytes int64) string {
 	const unit = 1024
 	if bytes < unit {
 		return fmt.Sprintf("%d B", bytes)
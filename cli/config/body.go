@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"time"
+)
+
+// BodyReadTimeout is how long a response body may go without delivering data before
+// the request is abandoned. Zero disables the check.
+var BodyReadTimeout = 30 * time.Second
+
+// stallReader aborts a response body read that makes no progress within timeout
+type stallReader struct {
+	r       io.Reader
+	timeout time.Duration
+	timer   *time.Timer
+	stalled atomic.Bool
+}
+
+// newStallReader wraps r, calling abort if no data is read for timeout
+func newStallReader(r io.Reader, timeout time.Duration, abort func()) *stallReader {
+	s := &stallReader{r: r, timeout: timeout}
+	s.timer = time.AfterFunc(timeout, func() {
+		s.stalled.Store(true)
+		abort()
+	})
+	return s
+}
+
+func (s *stallReader) Read(p []byte) (int, error) {
+	n, err := s.r.Read(p)
+	if s.stalled.Load() {
+		return n, fmt.Errorf("slow response body: no data received for %s", s.timeout)
+	}
+	if n > 0 {
+		s.timer.Reset(s.timeout)
+	}
+	return n, err
+}
+
+// stop releases the reader's timer
+func (s *stallReader) stop() {
+	s.timer.Stop()
+}
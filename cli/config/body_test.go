@@ -0,0 +1,68 @@
+package config
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sequinstream/sequin/cli/context"
+)
+
+func TestBodyReadTimeout(t *testing.T) {
+	defer func(timeout time.Duration) { BodyReadTimeout = timeout }(BodyReadTimeout)
+	BodyReadTimeout = 100 * time.Millisecond
+
+	t.Run("stalled body is abandoned", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"yaml": "`))
+			w.(http.Flusher).Flush()
+
+			// Headers and a partial body arrive quickly, then nothing until the client gives up
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+		}))
+		defer server.Close()
+
+		ctx := &context.Context{Hostname: strings.TrimPrefix(server.URL, "http://")}
+
+		start := time.Now()
+		_, err := Export(ctx, false)
+		elapsed := time.Since(start)
+
+		if err == nil || !strings.Contains(err.Error(), "slow response body") {
+			t.Errorf("Export() error = %v, want slow response body error", err)
+		}
+		if elapsed > 2*time.Second {
+			t.Errorf("Export() took %s, want it to give up shortly after %s", elapsed, BodyReadTimeout)
+		}
+	})
+
+	t.Run("slow but steady body succeeds", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			for _, chunk := range []string{`{"yaml": `, `"sinks: `, `[]"`, `}`} {
+				w.Write([]byte(chunk))
+				w.(http.Flusher).Flush()
+				time.Sleep(50 * time.Millisecond)
+			}
+		}))
+		defer server.Close()
+
+		ctx := &context.Context{Hostname: strings.TrimPrefix(server.URL, "http://")}
+
+		resp, err := Export(ctx, false)
+		if err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		if resp.YAML != "sinks: []" {
+			t.Errorf("Export() YAML = %q, want %q", resp.YAML, "sinks: []")
+		}
+	})
+}
@@ -2,13 +2,16 @@ package config
 
 import (
 	"bytes"
+	stdcontext "context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"os"
 
 	"github.com/a8m/envsubst"
+	"github.com/sequinstream/sequin/cli/api"
 	"github.com/sequinstream/sequin/cli/context"
 )
 
@@ -108,31 +111,10 @@ func Plan(ctx *context.Context, yamlPath string) (*PlanResponse, error) {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.ApiToken))
-
-	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned error: %s", string(body))
-	}
-
-	// Parse response
 	var planResp PlanResponse
-	if err := json.Unmarshal(body, &planResp); err != nil {
-		fmt.Println(string(body))
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := doRequest(ctx, req, &planResp); err != nil {
+		return nil, err
 	}
 
 	return &planResp, nil
@@ -176,31 +158,10 @@ func Apply(ctx *context.Context, yamlPath string) (*ApplyResponse, error) {
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.ApiToken))
-
-	// Send request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	// Read response
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned error: %s", string(body))
-	}
 
-	// Parse response
 	var applyResp ApplyResponse
-	if err := json.Unmarshal(body, &applyResp); err != nil {
-		fmt.Println(string(body))
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := doRequest(ctx, req, &applyResp); err != nil {
+		return nil, err
 	}
 
 	return &applyResp, nil
@@ -219,31 +180,74 @@ func Export(ctx *context.Context, showSensitive bool) (*ExportResponse, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
+	var exportResp ExportResponse
+	if err := doRequest(ctx, req, &exportResp); err != nil {
+		return nil, err
+	}
+
+	return &exportResp, nil
+}
+
+// maxResponseSize bounds how much of a response body is read into memory
+var maxResponseSize int64 = 32 << 20
+
+// doRequest sends an authenticated request and decodes a successful JSON response into out
+func doRequest(ctx *context.Context, req *http.Request, out interface{}) error {
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.ApiToken))
 
+	if err := interceptRequest(req); err != nil {
+		return err
+	}
+
+	// Cancelling the request is how a stalled response body is abandoned
+	reqCtx, cancel := stdcontext.WithCancel(req.Context())
+	defer cancel()
+	req = req.WithContext(reqCtx)
+
 	// Send request
-	client := &http.Client{}
+	client, err := context.NewHTTPClient(ctx)
+	if err != nil {
+		return err
+	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send request: %w", err)
+		return fmt.Errorf("failed to send request: %w", err)
 	}
 	defer resp.Body.Close()
 
-	// Read response
-	body, err := io.ReadAll(resp.Body)
+	if err := interceptResponse(resp); err != nil {
+		return err
+	}
+
+	var bodyReader io.Reader = resp.Body
+	if BodyReadTimeout > 0 {
+		stall := newStallReader(resp.Body, BodyReadTimeout, cancel)
+		defer stall.stop()
+		bodyReader = stall
+	}
+
+	// Read response, one byte past the limit so oversized bodies can be detected
+	body, err := io.ReadAll(io.LimitReader(bodyReader, maxResponseSize+1))
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if int64(len(body)) > maxResponseSize {
+		return fmt.Errorf("response body exceeds %d bytes", maxResponseSize)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned error: %s", string(body))
+		return api.ParseAPIError(resp.StatusCode, string(body))
+	}
+
+	contentType := resp.Header.Get("Content-Type")
+	if mediaType, _, _ := mime.ParseMediaType(contentType); mediaType != "application/json" {
+		return fmt.Errorf("unexpected response content type %q", contentType)
 	}
 
 	// Parse response
-	var exportResp ExportResponse
-	if err := json.Unmarshal(body, &exportResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	return &exportResp, nil
+	return nil
 }
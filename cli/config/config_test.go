@@ -2,10 +2,17 @@ package config
 
 import (
 	"bytes"
+	"errors"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/sequinstream/sequin/cli/api"
+	"github.com/sequinstream/sequin/cli/context"
 )
 
 func TestProcessEnvVars(t *testing.T) {
@@ -172,3 +179,127 @@ database: default_db
 		t.Errorf("Interpolate() expected error for nonexistent file, got nil")
 	}
 }
+
+func TestDoRequest(t *testing.T) {
+	// newServer starts a server that replies with the given status, content type, and body
+	newServer := func(status int, contentType, body string) (*httptest.Server, *context.Context) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(status)
+			io.WriteString(w, body)
+		}))
+		ctx := &context.Context{
+			Hostname: strings.TrimPrefix(server.URL, "http://"),
+			ApiToken: "test-token",
+		}
+		return server, ctx
+	}
+
+	t.Run("successful response", func(t *testing.T) {
+		server, ctx := newServer(http.StatusOK, "application/json; charset=utf-8", `{"yaml": "databases: []"}`)
+		defer server.Close()
+
+		resp, err := Export(ctx, false)
+		if err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+		if resp.YAML != "databases: []" {
+			t.Errorf("Export() YAML = %q, want %q", resp.YAML, "databases: []")
+		}
+	})
+
+	t.Run("error status returns APIError", func(t *testing.T) {
+		server, ctx := newServer(http.StatusNotFound, "application/json", `{"summary": "Not found"}`)
+		defer server.Close()
+
+		_, err := Export(ctx, false)
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) {
+			t.Fatalf("Export() error = %v, want *api.APIError", err)
+		}
+		if apiErr.StatusCode != http.StatusNotFound {
+			t.Errorf("APIError.StatusCode = %d, want %d", apiErr.StatusCode, http.StatusNotFound)
+		}
+		if apiErr.Summary != "Not found" {
+			t.Errorf("APIError.Summary = %q, want %q", apiErr.Summary, "Not found")
+		}
+	})
+
+	t.Run("validation error returns ValidationError", func(t *testing.T) {
+		server, ctx := newServer(http.StatusUnprocessableEntity, "application/json",
+			`{"summary": "Invalid YAML", "validation_errors": {"sinks": ["is invalid"]}}`)
+		defer server.Close()
+
+		yamlPath := filepath.Join(t.TempDir(), "sequin.yaml")
+		if err := os.WriteFile(yamlPath, []byte("sinks: []"), 0644); err != nil {
+			t.Fatalf("Failed to write test YAML: %v", err)
+		}
+
+		for name, call := range map[string]func() error{
+			"plan": func() error {
+				_, err := Plan(ctx, yamlPath)
+				return err
+			},
+			"apply": func() error {
+				_, err := Apply(ctx, yamlPath)
+				return err
+			},
+		} {
+			err := call()
+			var validationErr *api.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("%s error = %v, want *api.ValidationError", name, err)
+			}
+			if validationErr.Summary != "Invalid YAML" {
+				t.Errorf("%s ValidationError.Summary = %q, want %q", name, validationErr.Summary, "Invalid YAML")
+			}
+		}
+	})
+
+	t.Run("unexpected content type", func(t *testing.T) {
+		server, ctx := newServer(http.StatusOK, "text/html", "<html></html>")
+		defer server.Close()
+
+		_, err := Export(ctx, false)
+		if err == nil || !strings.Contains(err.Error(), "content type") {
+			t.Errorf("Export() error = %v, want content type error", err)
+		}
+	})
+
+	t.Run("oversized response", func(t *testing.T) {
+		server, ctx := newServer(http.StatusOK, "application/json", `{"yaml": "`+strings.Repeat("a", 64)+`"}`)
+		defer server.Close()
+
+		defer func(size int64) { maxResponseSize = size }(maxResponseSize)
+		maxResponseSize = 32
+
+		_, err := Export(ctx, false)
+		if err == nil || !strings.Contains(err.Error(), "exceeds") {
+			t.Errorf("Export() error = %v, want size limit error", err)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		server, ctx := newServer(http.StatusOK, "application/json", `{"yaml":`)
+		defer server.Close()
+
+		_, err := Export(ctx, false)
+		if err == nil || !strings.Contains(err.Error(), "failed to parse response") {
+			t.Errorf("Export() error = %v, want parse error", err)
+		}
+	})
+
+	t.Run("connection failure", func(t *testing.T) {
+		server, ctx := newServer(http.StatusOK, "application/json", `{}`)
+		server.Close()
+
+		_, err := Export(ctx, false)
+		if err == nil || !strings.Contains(err.Error(), "failed to send request") {
+			t.Errorf("Export() error = %v, want send error", err)
+		}
+	})
+}
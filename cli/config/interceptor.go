@@ -0,0 +1,48 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// RequestInterceptor inspects or modifies a request before it is sent
+type RequestInterceptor func(*http.Request) error
+
+var requestInterceptors []RequestInterceptor
+
+// AddRequestInterceptor registers an interceptor to run on every request before it is sent.
+// Interceptors run in the order they were added, and an interceptor returning an error aborts the request.
+func AddRequestInterceptor(interceptor RequestInterceptor) {
+	requestInterceptors = append(requestInterceptors, interceptor)
+}
+
+// interceptRequest runs the registered request interceptors against req
+func interceptRequest(req *http.Request) error {
+	for _, interceptor := range requestInterceptors {
+		if err := interceptor(req); err != nil {
+			return fmt.Errorf("request interceptor failed: %w", err)
+		}
+	}
+	return nil
+}
+
+// ResponseInterceptor inspects a response before its body is parsed
+type ResponseInterceptor func(*http.Response) error
+
+var responseInterceptors []ResponseInterceptor
+
+// AddResponseInterceptor registers an interceptor to run on every response, successful or not, before it is parsed.
+// Interceptors run in the order they were added, and an interceptor returning an error is surfaced to the caller.
+func AddResponseInterceptor(interceptor ResponseInterceptor) {
+	responseInterceptors = append(responseInterceptors, interceptor)
+}
+
+// interceptResponse runs the registered response interceptors against resp
+func interceptResponse(resp *http.Response) error {
+	for _, interceptor := range responseInterceptors {
+		if err := interceptor(resp); err != nil {
+			return fmt.Errorf("response interceptor failed: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sequinstream/sequin/cli/context"
+)
+
+func TestRequestInterceptors(t *testing.T) {
+	var received http.Header
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		received = r.Header.Clone()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"yaml": ""}`))
+	}))
+	defer server.Close()
+
+	ctx := &context.Context{Hostname: strings.TrimPrefix(server.URL, "http://")}
+
+	t.Run("interceptors run in order", func(t *testing.T) {
+		defer func() { requestInterceptors = nil }()
+
+		var order []string
+		AddRequestInterceptor(func(req *http.Request) error {
+			order = append(order, "first")
+			req.Header.Set("X-Signature", "first")
+			return nil
+		})
+		AddRequestInterceptor(func(req *http.Request) error {
+			order = append(order, "second")
+			req.Header.Set("X-Signature", req.Header.Get("X-Signature")+",second")
+			return nil
+		})
+
+		if _, err := Export(ctx, false); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+
+		if strings.Join(order, ",") != "first,second" {
+			t.Errorf("Interceptor order = %v, want [first second]", order)
+		}
+		if got := received.Get("X-Signature"); got != "first,second" {
+			t.Errorf("X-Signature header = %q, want %q", got, "first,second")
+		}
+	})
+
+	t.Run("interceptor error aborts request", func(t *testing.T) {
+		defer func() { requestInterceptors = nil }()
+
+		errSigning := errors.New("signing failed")
+		laterCalled := false
+		AddRequestInterceptor(func(req *http.Request) error {
+			return errSigning
+		})
+		AddRequestInterceptor(func(req *http.Request) error {
+			laterCalled = true
+			return nil
+		})
+
+		requests = 0
+		_, err := Export(ctx, false)
+		if !errors.Is(err, errSigning) {
+			t.Errorf("Export() error = %v, want %v", err, errSigning)
+		}
+		if laterCalled {
+			t.Error("Expected interceptors after the failing one to be skipped")
+		}
+		if requests != 0 {
+			t.Errorf("Expected no requests to reach the server, got %d", requests)
+		}
+	})
+}
+
+func TestResponseInterceptors(t *testing.T) {
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"yaml": ""}`))
+	}))
+	defer server.Close()
+
+	ctx := &context.Context{Hostname: strings.TrimPrefix(server.URL, "http://")}
+
+	t.Run("runs on success and error responses", func(t *testing.T) {
+		defer func() { responseInterceptors = nil }()
+
+		var seen []int
+		var remaining string
+		AddResponseInterceptor(func(resp *http.Response) error {
+			seen = append(seen, resp.StatusCode)
+			return nil
+		})
+		AddResponseInterceptor(func(resp *http.Response) error {
+			remaining = resp.Header.Get("X-RateLimit-Remaining")
+			return nil
+		})
+
+		status = http.StatusOK
+		if _, err := Export(ctx, false); err != nil {
+			t.Fatalf("Export() error = %v", err)
+		}
+
+		status = http.StatusInternalServerError
+		if _, err := Export(ctx, false); err == nil {
+			t.Fatal("Export() expected error for 500 response, got nil")
+		}
+
+		if len(seen) != 2 || seen[0] != http.StatusOK || seen[1] != http.StatusInternalServerError {
+			t.Errorf("Interceptor saw statuses %v, want [200 500]", seen)
+		}
+		if remaining != "42" {
+			t.Errorf("X-RateLimit-Remaining = %q, want %q", remaining, "42")
+		}
+	})
+
+	t.Run("interceptor error surfaces to caller", func(t *testing.T) {
+		defer func() { responseInterceptors = nil }()
+
+		errMetrics := errors.New("metrics failed")
+		AddResponseInterceptor(func(resp *http.Response) error {
+			return errMetrics
+		})
+
+		status = http.StatusOK
+		_, err := Export(ctx, false)
+		if !errors.Is(err, errMetrics) {
+			t.Errorf("Export() error = %v, want %v", err, errMetrics)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+package context
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+)
+
+// MaxRedirects is the maximum number of redirects followed for a single request
+var MaxRedirects = 10
+
+// RedirectError is returned when the server redirects a request that can't be safely followed
+type RedirectError struct {
+	Method   string
+	Location string
+}
+
+// Error implements the error interface for RedirectError
+func (e *RedirectError) Error() string {
+	return fmt.Sprintf("server redirected %s request to %s; update the context hostname or TLS setting to point there directly", e.Method, e.Location)
+}
+
+// SetClientCertificate configures the client certificate presented to servers that require mutual TLS
+func SetClientCertificate(ctx *Context, certFile, keyFile string) error {
+	if certFile == "" || keyFile == "" {
+		return fmt.Errorf("both a client certificate and a client key are required")
+	}
+
+	// Fail early if the pair can't be loaded or the key doesn't match the certificate
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		return fmt.Errorf("could not load client certificate: %w", err)
+	}
+
+	// Store absolute paths so the context works from any directory
+	certPath, err := filepath.Abs(certFile)
+	if err != nil {
+		return fmt.Errorf("could not resolve client certificate path: %w", err)
+	}
+	keyPath, err := filepath.Abs(keyFile)
+	if err != nil {
+		return fmt.Errorf("could not resolve client key path: %w", err)
+	}
+
+	ctx.ClientCertFile = certPath
+	ctx.ClientKeyFile = keyPath
+	return nil
+}
+
+// NewHTTPClient returns an HTTP client whose transport is configured for the context
+func NewHTTPClient(ctx *Context) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if ctx.ClientCertFile != "" || ctx.ClientKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(ctx.ClientCertFile, ctx.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client certificate: %w", err)
+		}
+		transport.TLSClientConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+		}
+	}
+
+	return &http.Client{Transport: transport, CheckRedirect: checkRedirect}, nil
+}
+
+// checkRedirect follows GET redirects up to MaxRedirects, keeping auth on the same host.
+// Other methods would lose their body or be replayed, so the redirect is returned as an error instead.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	original := via[0]
+	if original.Method != http.MethodGet && original.Method != http.MethodHead {
+		return &RedirectError{Method: original.Method, Location: req.URL.String()}
+	}
+
+	if len(via) > MaxRedirects {
+		return fmt.Errorf("stopped after %d redirects", MaxRedirects)
+	}
+
+	// Go only forwards Authorization to the same domain; make sure it survives scheme or port changes too
+	if req.URL.Hostname() == original.URL.Hostname() {
+		if auth := original.Header.Get("Authorization"); auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+	}
+
+	log.Printf("Following redirect from %s to %s", via[len(via)-1].URL, req.URL)
+	return nil
+}
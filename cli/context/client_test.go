@@ -0,0 +1,223 @@
+package context
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeCertPair generates a certificate signed by parent (or self-signed if parent is nil)
+// and writes it and its key as PEM files to dir
+func writeCertPair(t *testing.T, dir, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey, string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("Failed to parse certificate: %v", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatalf("Failed to write certificate: %v", err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+
+	return cert, key, certFile, keyFile
+}
+
+func TestSetClientCertificate(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	caCert, caKey, _, _ := writeCertPair(t, tmpDir, "ca", nil, nil)
+	_, _, certFile, keyFile := writeCertPair(t, tmpDir, "client", caCert, caKey)
+	_, _, _, otherKeyFile := writeCertPair(t, tmpDir, "other", caCert, caKey)
+
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{
+		ClientAuth: tls.RequireAndVerifyClientCert,
+		ClientCAs:  caPool,
+	}
+	server.StartTLS()
+	defer server.Close()
+
+	serverPool := x509.NewCertPool()
+	serverPool.AddCert(server.Certificate())
+
+	// newClient builds the context's client, trusting the test server's certificate
+	newClient := func(t *testing.T, ctx *Context) *http.Client {
+		client, err := NewHTTPClient(ctx)
+		if err != nil {
+			t.Fatalf("NewHTTPClient() error = %v", err)
+		}
+		transport := client.Transport.(*http.Transport)
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.RootCAs = serverPool
+		return client
+	}
+
+	t.Run("request with client certificate succeeds", func(t *testing.T) {
+		ctx := &Context{}
+		if err := SetClientCertificate(ctx, certFile, keyFile); err != nil {
+			t.Fatalf("SetClientCertificate() error = %v", err)
+		}
+
+		resp, err := newClient(t, ctx).Get(server.URL)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("request without client certificate fails", func(t *testing.T) {
+		resp, err := newClient(t, &Context{}).Get(server.URL)
+		if err == nil {
+			resp.Body.Close()
+			t.Error("Expected request without client certificate to fail")
+		}
+	})
+
+	t.Run("mismatched key", func(t *testing.T) {
+		ctx := &Context{}
+		if err := SetClientCertificate(ctx, certFile, otherKeyFile); err == nil {
+			t.Error("Expected error for mismatched certificate and key, got nil")
+		}
+		if ctx.ClientCertFile != "" || ctx.ClientKeyFile != "" {
+			t.Error("Expected context to be left unchanged on error")
+		}
+	})
+
+	t.Run("missing files", func(t *testing.T) {
+		ctx := &Context{}
+		if err := SetClientCertificate(ctx, filepath.Join(tmpDir, "missing.crt"), keyFile); err == nil {
+			t.Error("Expected error for missing certificate file, got nil")
+		}
+		if err := SetClientCertificate(ctx, certFile, ""); err == nil {
+			t.Error("Expected error for missing key path, got nil")
+		}
+	})
+}
+
+func TestRedirects(t *testing.T) {
+	var finalAuth string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/old", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {
+		finalAuth = r.Header.Get("Authorization")
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/loop", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client, err := NewHTTPClient(&Context{})
+	if err != nil {
+		t.Fatalf("NewHTTPClient() error = %v", err)
+	}
+
+	t.Run("GET redirect is followed with auth", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", server.URL+"/old", nil)
+		req.Header.Set("Authorization", "Bearer token")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("Expected status 200, got %d", resp.StatusCode)
+		}
+		if finalAuth != "Bearer token" {
+			t.Errorf("Authorization after redirect = %q, want %q", finalAuth, "Bearer token")
+		}
+	})
+
+	t.Run("POST redirect is surfaced", func(t *testing.T) {
+		resp, err := client.Post(server.URL+"/old", "application/json", strings.NewReader("{}"))
+		if err == nil {
+			resp.Body.Close()
+			t.Fatal("Expected POST redirect to return an error")
+		}
+
+		var redirectErr *RedirectError
+		if !errors.As(err, &redirectErr) {
+			t.Fatalf("Expected *RedirectError, got %v", err)
+		}
+		if redirectErr.Method != "POST" || redirectErr.Location != server.URL+"/new" {
+			t.Errorf("RedirectError = %+v, want POST to %s/new", redirectErr, server.URL)
+		}
+	})
+
+	t.Run("max hops", func(t *testing.T) {
+		defer func(max int) { MaxRedirects = max }(MaxRedirects)
+		MaxRedirects = 3
+
+		resp, err := client.Get(server.URL + "/loop")
+		if err == nil {
+			resp.Body.Close()
+			t.Fatal("Expected redirect loop to return an error")
+		}
+		if !strings.Contains(err.Error(), "stopped after 3 redirects") {
+			t.Errorf("Expected max redirects error, got %v", err)
+		}
+	})
+}
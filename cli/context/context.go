@@ -18,6 +18,9 @@ type Context struct {
 	Default       bool                `json:"default"`
 	ApiToken      string              `json:"api_token"`
 	TunnelPorts   []map[string]string `json:"tunnelPorts,omitempty"`
+
+	ClientCertFile string `json:"client_cert_file,omitempty"`
+	ClientKeyFile  string `json:"client_key_file,omitempty"`
 }
 
 var defaultContext = Context{
@@ -28,6 +31,34 @@ var defaultContext = Context{
 	PortalBaseURL: "https://portal.sequinstream.com",
 }
 
+// configPath overrides the directory contexts are stored in, see SetConfigPath
+var configPath string
+
+// SetConfigPath sets the directory contexts are stored in.
+// The directory is resolved in order of precedence: the path set here,
+// then the SEQUIN_CONFIG environment variable, then ~/.sequin.
+func SetConfigPath(path string) {
+	configPath = path
+}
+
+// configDir returns the resolved directory contexts are stored in
+func configDir() (string, error) {
+	if configPath != "" {
+		return configPath, nil
+	}
+
+	if path := os.Getenv("SEQUIN_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get user home directory: %w", err)
+	}
+
+	return filepath.Join(home, ".sequin"), nil
+}
+
 // GetServerURL returns the server URL based on the current context
 func GetServerURL(ctx *Context) (string, error) {
 	if ctx.Hostname == "" {
@@ -43,12 +74,12 @@ func GetServerURL(ctx *Context) (string, error) {
 }
 
 func SaveContext(ctx Context) error {
-	home, err := os.UserHomeDir()
+	root, err := configDir()
 	if err != nil {
-		return fmt.Errorf("could not get user home directory: %w", err)
+		return err
 	}
 
-	dir := filepath.Join(home, ".sequin", "contexts")
+	dir := filepath.Join(root, "contexts")
 	err = os.MkdirAll(dir, 0755)
 	if err != nil {
 		return fmt.Errorf("could not create contexts directory: %w", err)
@@ -81,12 +112,12 @@ func LoadContext(name string) (*Context, error) {
 		name = defaultName
 	}
 
-	home, err := os.UserHomeDir()
+	root, err := configDir()
 	if err != nil {
-		return nil, fmt.Errorf("could not get user home directory: %w", err)
+		return nil, err
 	}
 
-	file := filepath.Join(home, ".sequin", "contexts", name+".json")
+	file := filepath.Join(root, "contexts", name+".json")
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return nil, fmt.Errorf("could not read context file: %w", err)
@@ -116,12 +147,12 @@ func LoadContext(name string) (*Context, error) {
 }
 
 func ListContexts() ([]Context, error) {
-	home, err := os.UserHomeDir()
+	root, err := configDir()
 	if err != nil {
-		return nil, fmt.Errorf("could not get user home directory: %w", err)
+		return nil, err
 	}
 
-	dir := filepath.Join(home, ".sequin", "contexts")
+	dir := filepath.Join(root, "contexts")
 	files, err := os.ReadDir(dir)
 
 	if err != nil {
@@ -148,12 +179,12 @@ func ListContexts() ([]Context, error) {
 }
 
 func RemoveContext(name string) error {
-	home, err := os.UserHomeDir()
+	root, err := configDir()
 	if err != nil {
-		return fmt.Errorf("could not get user home directory: %w", err)
+		return err
 	}
 
-	file := filepath.Join(home, ".sequin", "contexts", name+".json")
+	file := filepath.Join(root, "contexts", name+".json")
 	err = os.Remove(file)
 	if err != nil {
 		return fmt.Errorf("could not remove context file: %w", err)
@@ -173,15 +204,14 @@ func RemoveContext(name string) error {
 const defaultContextFile = ".default_context"
 
 func SetDefaultContext(name string) error {
-	home, err := os.UserHomeDir()
+	dir, err := configDir()
 	if err != nil {
-		return fmt.Errorf("could not get user home directory: %w", err)
+		return err
 	}
 
-	dir := filepath.Join(home, ".sequin")
 	err = os.MkdirAll(dir, 0755)
 	if err != nil {
-		return fmt.Errorf("could not create .sequin directory: %w", err)
+		return fmt.Errorf("could not create config directory: %w", err)
 	}
 
 	file := filepath.Join(dir, defaultContextFile)
@@ -194,12 +224,12 @@ func SetDefaultContext(name string) error {
 }
 
 func getDefaultContextName() (string, error) {
-	home, err := os.UserHomeDir()
+	root, err := configDir()
 	if err != nil {
-		return "", fmt.Errorf("could not get user home directory: %w", err)
+		return "", err
 	}
 
-	file := filepath.Join(home, ".sequin", defaultContextFile)
+	file := filepath.Join(root, defaultContextFile)
 	data, err := os.ReadFile(file)
 	if err != nil {
 		return "", err
@@ -209,12 +239,12 @@ func getDefaultContextName() (string, error) {
 }
 
 func removeDefaultContext() error {
-	home, err := os.UserHomeDir()
+	root, err := configDir()
 	if err != nil {
-		return fmt.Errorf("could not get user home directory: %w", err)
+		return err
 	}
 
-	file := filepath.Join(home, ".sequin", defaultContextFile)
+	file := filepath.Join(root, defaultContextFile)
 	err = os.Remove(file)
 	if err != nil {
 		return fmt.Errorf("could not remove default context file: %w", err)
@@ -0,0 +1,81 @@
+package context
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigPath(t *testing.T) {
+	defer SetConfigPath("")
+
+	t.Run("explicit path", func(t *testing.T) {
+		dir := t.TempDir()
+		SetConfigPath(dir)
+		defer SetConfigPath("")
+
+		ctx := Context{Name: "test", Hostname: "localhost:7376", ApiToken: "token"}
+		if err := SaveContext(ctx); err != nil {
+			t.Fatalf("SaveContext() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "contexts", "test.json")); err != nil {
+			t.Errorf("Expected context file in config path: %v", err)
+		}
+
+		loaded, err := LoadContext("test")
+		if err != nil {
+			t.Fatalf("LoadContext() error = %v", err)
+		}
+		if loaded.Hostname != ctx.Hostname || loaded.ApiToken != ctx.ApiToken {
+			t.Errorf("LoadContext() = %+v, want %+v", loaded, ctx)
+		}
+
+		if err := SetDefaultContext("test"); err != nil {
+			t.Fatalf("SetDefaultContext() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, defaultContextFile)); err != nil {
+			t.Errorf("Expected default context file in config path: %v", err)
+		}
+	})
+
+	t.Run("environment variable", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("SEQUIN_CONFIG", dir)
+
+		if err := SaveContext(Context{Name: "from-env"}); err != nil {
+			t.Fatalf("SaveContext() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "contexts", "from-env.json")); err != nil {
+			t.Errorf("Expected context file in SEQUIN_CONFIG: %v", err)
+		}
+	})
+
+	t.Run("explicit path takes precedence over environment", func(t *testing.T) {
+		explicitDir := t.TempDir()
+		t.Setenv("SEQUIN_CONFIG", t.TempDir())
+		SetConfigPath(explicitDir)
+		defer SetConfigPath("")
+
+		got, err := configDir()
+		if err != nil {
+			t.Fatalf("configDir() error = %v", err)
+		}
+		if got != explicitDir {
+			t.Errorf("configDir() = %q, want %q", got, explicitDir)
+		}
+	})
+
+	t.Run("defaults to home directory", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		t.Setenv("SEQUIN_CONFIG", "")
+
+		got, err := configDir()
+		if err != nil {
+			t.Fatalf("configDir() error = %v", err)
+		}
+		if want := filepath.Join(home, ".sequin"); got != want {
+			t.Errorf("configDir() = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,51 @@
+package context
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+var (
+	// ErrServerUnreachable is returned by Verify when no response is received from the server
+	ErrServerUnreachable = errors.New("server unreachable")
+	// ErrUnauthorized is returned by Verify when the server rejects the context's API token
+	ErrUnauthorized = errors.New("authentication failed")
+)
+
+// Verify checks that the context's server is reachable and accepts its API token.
+// It returns nil when both hold, and otherwise an error wrapping ErrServerUnreachable or ErrUnauthorized.
+func Verify(ctx *Context) error {
+	serverURL, err := GetServerURL(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Any authenticated endpoint will do; local tunnels is small and available to every account
+	req, err := http.NewRequest("GET", serverURL+"/api/local_tunnels", nil)
+	if err != nil {
+		return fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", ctx.ApiToken))
+
+	client, err := NewHTTPClient(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrServerUnreachable, serverURL, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%w: %s rejected the API token (status code %d)", ErrUnauthorized, serverURL, resp.StatusCode)
+	default:
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+}
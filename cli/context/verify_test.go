@@ -0,0 +1,59 @@
+package context
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") {
+		case "Bearer valid":
+			w.WriteHeader(http.StatusOK)
+		case "Bearer forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "Bearer broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer server.Close()
+
+	hostname := strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("reachable and authorized", func(t *testing.T) {
+		if err := Verify(&Context{Hostname: hostname, ApiToken: "valid"}); err != nil {
+			t.Errorf("Verify() error = %v, want nil", err)
+		}
+	})
+
+	t.Run("auth failed", func(t *testing.T) {
+		for _, token := range []string{"invalid", "forbidden"} {
+			err := Verify(&Context{Hostname: hostname, ApiToken: token})
+			if !errors.Is(err, ErrUnauthorized) {
+				t.Errorf("Verify() with token %q error = %v, want ErrUnauthorized", token, err)
+			}
+		}
+	})
+
+	t.Run("unexpected status", func(t *testing.T) {
+		err := Verify(&Context{Hostname: hostname, ApiToken: "broken"})
+		if err == nil || errors.Is(err, ErrUnauthorized) || errors.Is(err, ErrServerUnreachable) {
+			t.Errorf("Verify() error = %v, want unexpected status error", err)
+		}
+	})
+
+	t.Run("server unreachable", func(t *testing.T) {
+		unreachable := httptest.NewServer(http.NotFoundHandler())
+		unreachable.Close()
+
+		err := Verify(&Context{Hostname: strings.TrimPrefix(unreachable.URL, "http://"), ApiToken: "valid"})
+		if !errors.Is(err, ErrServerUnreachable) {
+			t.Errorf("Verify() error = %v, want ErrServerUnreachable", err)
+		}
+	})
+}